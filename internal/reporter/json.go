@@ -0,0 +1,183 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/submariner-io/submariner-operator/pkg/eventreporter"
+)
+
+// jsonReporter implements eventreporter.Reporter by emitting a newline-delimited JSON event
+// stream, suitable for consumption by CI or addon controllers that invoke subctl programmatically.
+type jsonReporter struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	seq     int
+	stack   []jsonOperation
+}
+
+type jsonOperation struct {
+	id     string
+	parent string
+	start  time.Time
+}
+
+func NewJSONReporter(w io.Writer) eventreporter.Reporter {
+	return &jsonReporter{encoder: json.NewEncoder(w)}
+}
+
+func (r *jsonReporter) Started(message string, args ...interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	op := jsonOperation{id: r.nextID(), parent: r.currentID(), start: time.Now()}
+	r.stack = append(r.stack, op)
+
+	r.emit(&eventreporter.Event{
+		Kind:        eventreporter.EventStarted,
+		OperationID: op.id,
+		ParentID:    op.parent,
+		Message:     fmt.Sprintf(message, args...),
+		Timestamp:   op.start,
+	})
+}
+
+func (r *jsonReporter) Succeeded(message string, args ...interface{}) {
+	if message == "" {
+		return
+	}
+
+	r.emitForCurrent(eventreporter.EventSucceeded, fmt.Sprintf(message, args...))
+}
+
+func (r *jsonReporter) Warned(message string) {
+	if message == "" {
+		return
+	}
+
+	r.emitForCurrent(eventreporter.EventWarned, message)
+}
+
+func (r *jsonReporter) Failed(message string) {
+	if message == "" {
+		return
+	}
+
+	r.emitForCurrent(eventreporter.EventFailed, message)
+}
+
+func (r *jsonReporter) EndedWith(err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.stack) == 0 {
+		return
+	}
+
+	op := r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+
+	status := eventreporter.EndStatusSuccess
+
+	message := ""
+	if err != nil {
+		status = eventreporter.EndStatusFailure
+		message = err.Error()
+	}
+
+	r.emit(&eventreporter.Event{
+		Kind:            eventreporter.EventEnded,
+		OperationID:     op.id,
+		ParentID:        op.parent,
+		Status:          status,
+		Message:         message,
+		Timestamp:       time.Now(),
+		DurationSeconds: time.Since(op.start).Seconds(),
+	})
+}
+
+func (r *jsonReporter) Step(current, total int, message string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.emit(&eventreporter.Event{
+		Kind:        eventreporter.EventProgress,
+		OperationID: r.currentID(),
+		ParentID:    r.parentOfCurrent(),
+		Message:     message,
+		Timestamp:   time.Now(),
+		Current:     current,
+		Total:       total,
+	})
+}
+
+func (r *jsonReporter) SubReporter(name string) eventreporter.Reporter {
+	return eventreporter.NewSubReporter(r, name)
+}
+
+func (r *jsonReporter) emitForCurrent(kind eventreporter.EventKind, message string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.emit(&eventreporter.Event{
+		Kind:        kind,
+		OperationID: r.currentID(),
+		ParentID:    r.parentOfCurrent(),
+		Message:     message,
+		Timestamp:   time.Now(),
+	})
+}
+
+// emit must be called with mutex held.
+func (r *jsonReporter) emit(event *eventreporter.Event) {
+	// The encoder writes to the underlying writer directly; an encoding error here would mean
+	// the destination (e.g. stdout) is broken, which we have no good way to report back.
+	_ = r.encoder.Encode(event)
+}
+
+// currentID must be called with mutex held.
+func (r *jsonReporter) currentID() string {
+	if len(r.stack) == 0 {
+		return ""
+	}
+
+	return r.stack[len(r.stack)-1].id
+}
+
+// parentOfCurrent must be called with mutex held.
+func (r *jsonReporter) parentOfCurrent() string {
+	if len(r.stack) == 0 {
+		return ""
+	}
+
+	return r.stack[len(r.stack)-1].parent
+}
+
+// nextID must be called with mutex held.
+func (r *jsonReporter) nextID() string {
+	r.seq++
+
+	return "op-" + strconv.Itoa(r.seq)
+}
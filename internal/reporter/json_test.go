@@ -0,0 +1,112 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/submariner-io/submariner-operator/pkg/eventreporter"
+)
+
+func decodeEvents(t *testing.T, buf *bytes.Buffer) []eventreporter.Event {
+	t.Helper()
+
+	var events []eventreporter.Event
+
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var event eventreporter.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("error decoding event %q: %v", scanner.Text(), err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func TestJSONReporterEmitsNestedOperationIDs(t *testing.T) {
+	var buf bytes.Buffer
+
+	r := NewJSONReporter(&buf)
+
+	r.Started("outer")
+	sub := r.SubReporter("inner")
+	sub.Started("inner work")
+	sub.EndedWith(nil)
+	r.EndedWith(nil)
+
+	events := decodeEvents(t, &buf)
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4: %+v", len(events), events)
+	}
+
+	outerStarted, innerStarted, innerEnded, outerEnded := events[0], events[1], events[2], events[3]
+
+	if outerStarted.Kind != eventreporter.EventStarted || outerStarted.ParentID != "" {
+		t.Errorf("unexpected outer Started event: %+v", outerStarted)
+	}
+
+	if innerStarted.Kind != eventreporter.EventStarted || innerStarted.ParentID != outerStarted.OperationID {
+		t.Errorf("inner Started event isn't nested under the outer operation: %+v", innerStarted)
+	}
+
+	if innerEnded.OperationID != innerStarted.OperationID || innerEnded.Status != eventreporter.EndStatusSuccess {
+		t.Errorf("unexpected inner EndedWith event: %+v", innerEnded)
+	}
+
+	if outerEnded.OperationID != outerStarted.OperationID || outerEnded.Status != eventreporter.EndStatusSuccess {
+		t.Errorf("unexpected outer EndedWith event: %+v", outerEnded)
+	}
+}
+
+func TestJSONReporterEndedWithReportsFailureStatus(t *testing.T) {
+	var buf bytes.Buffer
+
+	r := NewJSONReporter(&buf)
+
+	r.Started("work")
+	r.EndedWith(errors.New("boom"))
+
+	events := decodeEvents(t, &buf)
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(events), events)
+	}
+
+	ended := events[1]
+	if ended.Status != eventreporter.EndStatusFailure || ended.Message != "boom" {
+		t.Errorf("unexpected EndedWith event: %+v", ended)
+	}
+}
+
+func TestJSONReporterEndedWithOnEmptyStackIsANoOp(t *testing.T) {
+	var buf bytes.Buffer
+
+	r := NewJSONReporter(&buf)
+	r.EndedWith(nil)
+
+	if buf.Len() != 0 {
+		t.Fatalf("expected no events to be emitted, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,235 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/pkg/eventreporter"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+)
+
+// BrokerDeployedCondition is the status.Conditions Type set by the K8s reporter when Finish is
+// called on the owner.
+const BrokerDeployedCondition = "SubmarinerBrokerDeployed"
+
+// Owner is the object the K8s reporter records Events against and updates Conditions on, e.g. the
+// Broker CR created by brokercr.Ensure.
+type Owner interface {
+	metav1.Object
+	runtime.Object
+}
+
+// K8sReporter implements eventreporter.Reporter by recording each call as a Kubernetes Event on
+// the owner object. It does not infer the overall run's terminal status from Started/EndedWith
+// balance, since a multi-phase caller such as deploy.Broker has several sibling top-level phases
+// rather than one enclosing pair; callers must call Finish explicitly once the whole run is over,
+// which folds the result into a status.Conditions entry so controllers can observe it through the
+// API server instead of stdout.
+type K8sReporter struct {
+	recorder      record.EventRecorder
+	owner         Owner
+	dynamicClient dynamic.NamespaceableResourceInterface
+
+	mutex sync.Mutex
+	stack []k8sOperation
+}
+
+type k8sOperation struct {
+	message string
+	start   time.Time
+}
+
+// NewK8sReporter returns a Reporter that records progress as Events on owner. Call Finish once
+// the whole run has completed to record its terminal status as a Condition.
+func NewK8sReporter(cfg *rest.Config, owner Owner, scheme *runtime.Scheme) (*K8sReporter, error) {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating Kubernetes clientset")
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating dynamic client")
+	}
+
+	gvk := owner.GetObjectKind().GroupVersionKind()
+	gvr := gvk.GroupVersion().WithResource(meta.UnsafeGuessKindToResource(gvk).Resource)
+
+	return &K8sReporter{
+		recorder:      newEventRecorder(clientset, scheme),
+		owner:         owner,
+		dynamicClient: dynamicClient.Resource(gvr),
+	}, nil
+}
+
+func newEventRecorder(clientset kubernetes.Interface, objScheme *runtime.Scheme) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events("")})
+
+	return broadcaster.NewRecorder(objScheme, v1.EventSource{Component: "subctl"})
+}
+
+func (r *K8sReporter) Started(message string, args ...interface{}) {
+	r.mutex.Lock()
+	r.stack = append(r.stack, k8sOperation{message: fmt.Sprintf(message, args...), start: time.Now()})
+	r.mutex.Unlock()
+
+	r.recorder.Event(r.owner, v1.EventTypeNormal, "Started", fmt.Sprintf(message, args...))
+}
+
+func (r *K8sReporter) Succeeded(message string, args ...interface{}) {
+	if message == "" {
+		return
+	}
+
+	r.recorder.Event(r.owner, v1.EventTypeNormal, "Succeeded", fmt.Sprintf(message, args...))
+}
+
+func (r *K8sReporter) Warned(message string) {
+	if message == "" {
+		return
+	}
+
+	r.recorder.Event(r.owner, v1.EventTypeWarning, "Warning", message)
+}
+
+func (r *K8sReporter) Failed(message string) {
+	if message == "" {
+		return
+	}
+
+	r.recorder.Event(r.owner, v1.EventTypeWarning, "Failed", message)
+}
+
+func (r *K8sReporter) EndedWith(err error) {
+	r.mutex.Lock()
+
+	if len(r.stack) == 0 {
+		r.mutex.Unlock()
+		return
+	}
+
+	op := r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+
+	r.mutex.Unlock()
+
+	if err != nil {
+		r.recorder.Eventf(r.owner, v1.EventTypeWarning, "Failed", "%s: %s", op.message, err)
+	} else {
+		r.recorder.Event(r.owner, v1.EventTypeNormal, "Succeeded", op.message)
+	}
+}
+
+// Finish records the terminal status of the whole run as a status.Conditions entry on the owner.
+// It must be called explicitly by the caller once every phase is complete; it can't be inferred
+// from Started/EndedWith balance, because a multi-phase caller such as deploy.Broker has several
+// sibling top-level phases, and some of its success paths return without ever calling EndedWith.
+func (r *K8sReporter) Finish(err error) {
+	if patchErr := r.patchCondition(err); patchErr != nil {
+		r.recorder.Eventf(r.owner, v1.EventTypeWarning, "ConditionUpdateFailed",
+			"Failed to update the %s condition: %s", BrokerDeployedCondition, patchErr)
+	}
+}
+
+func (r *K8sReporter) patchCondition(deployErr error) error {
+	condition := metav1.Condition{
+		Type:               BrokerDeployedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DeploySucceeded",
+		Message:            "The broker was successfully deployed",
+		LastTransitionTime: metav1.Now(),
+	}
+
+	if deployErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "DeployFailed"
+		condition.Message = deployErr.Error()
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []metav1.Condition{condition},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "error marshalling the condition patch")
+	}
+
+	_, err = r.dynamicClient.Namespace(r.owner.GetNamespace()).Patch(context.TODO(), r.owner.GetName(), types.MergePatchType,
+		patch, metav1.PatchOptions{}, "status")
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	return errors.Wrap(err, "error patching status conditions")
+}
+
+// progressAnnotation records the most recent Step call, so controllers watching the owner object
+// can surface deploy progress without tailing subctl's own output.
+const progressAnnotation = "submariner.io/progress"
+
+func (r *K8sReporter) Step(current, total int, message string) {
+	r.recorder.Eventf(r.owner, v1.EventTypeNormal, "Progress", "(%d/%d) %s", current, total, message)
+
+	if err := r.patchProgressAnnotation(current, total, message); err != nil {
+		r.recorder.Eventf(r.owner, v1.EventTypeWarning, "ProgressUpdateFailed", "Failed to update the progress annotation: %s", err)
+	}
+}
+
+func (r *K8sReporter) patchProgressAnnotation(current, total int, message string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				progressAnnotation: fmt.Sprintf("%d/%d %s", current, total, message),
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "error marshalling the progress patch")
+	}
+
+	_, err = r.dynamicClient.Namespace(r.owner.GetNamespace()).Patch(context.TODO(), r.owner.GetName(), types.MergePatchType,
+		patch, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	return errors.Wrap(err, "error patching the progress annotation")
+}
+
+func (r *K8sReporter) SubReporter(name string) eventreporter.Reporter {
+	return eventreporter.NewSubReporter(r, name)
+}
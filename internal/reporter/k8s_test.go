@@ -0,0 +1,134 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+var brokerGVR = schema.GroupVersionResource{Group: "submariner.io", Version: "v1alpha1", Resource: "brokers"}
+
+// newTestK8sReporter builds a K8sReporter directly, bypassing NewK8sReporter's real clientset
+// construction, so its behaviour can be driven against fakes instead of a live cluster.
+func newTestK8sReporter(t *testing.T) (*K8sReporter, *dynamicfake.FakeDynamicClient) {
+	t.Helper()
+
+	owner := &unstructured.Unstructured{}
+	owner.SetAPIVersion("submariner.io/v1alpha1")
+	owner.SetKind("Broker")
+	owner.SetName("test-broker")
+	owner.SetNamespace("test-ns")
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{brokerGVR: "BrokerList"}, owner)
+
+	return &K8sReporter{
+		recorder:      record.NewFakeRecorder(100),
+		owner:         owner,
+		dynamicClient: dynamicClient.Resource(brokerGVR),
+	}, dynamicClient
+}
+
+func getCondition(t *testing.T, client *dynamicfake.FakeDynamicClient) (string, string) {
+	t.Helper()
+
+	obj, err := client.Resource(brokerGVR).Namespace("test-ns").Get(context.TODO(), "test-broker", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error fetching the owner: %v", err)
+	}
+
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		t.Fatalf("error reading status.conditions: %v", err)
+	}
+
+	if len(conditions) == 0 {
+		return "", ""
+	}
+
+	condition, _ := conditions[0].(map[string]interface{})
+
+	status, _ := condition["status"].(string)
+	reason, _ := condition["reason"].(string)
+
+	return status, reason
+}
+
+func TestK8sReporterEndedWithDoesNotPatchTheConditionOnItsOwn(t *testing.T) {
+	r, client := newTestK8sReporter(t)
+
+	// A single phase's Started/EndedWith pair emptying the stack must not be mistaken for the
+	// whole run completing: only an explicit Finish call should patch the condition.
+	r.Started("phase one")
+	r.EndedWith(nil)
+
+	if status, _ := getCondition(t, client); status != "" {
+		t.Fatalf("EndedWith patched the condition on its own: status=%s", status)
+	}
+}
+
+func TestK8sReporterFinishPatchesConditionTrueOnSuccess(t *testing.T) {
+	r, client := newTestK8sReporter(t)
+
+	r.Started("phase one")
+	r.EndedWith(nil)
+	r.Finish(nil)
+
+	status, reason := getCondition(t, client)
+	if status != string(metav1.ConditionTrue) || reason != "DeploySucceeded" {
+		t.Fatalf("unexpected condition after Finish(nil): status=%s reason=%s", status, reason)
+	}
+}
+
+func TestK8sReporterFinishPatchesConditionFalseOnError(t *testing.T) {
+	r, client := newTestK8sReporter(t)
+
+	r.Started("phase one")
+	r.EndedWith(errors.New("boom"))
+	r.Finish(errors.New("boom"))
+
+	status, reason := getCondition(t, client)
+	if status != string(metav1.ConditionFalse) || reason != "DeployFailed" {
+		t.Fatalf("unexpected condition after Finish(err): status=%s reason=%s", status, reason)
+	}
+}
+
+func TestK8sReporterStepPatchesProgressAnnotation(t *testing.T) {
+	r, client := newTestK8sReporter(t)
+
+	r.Step(2, 5, "Deploying the Submariner operator")
+
+	obj, err := client.Resource(brokerGVR).Namespace("test-ns").Get(context.TODO(), "test-broker", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error fetching the owner: %v", err)
+	}
+
+	if got := obj.GetAnnotations()[progressAnnotation]; got != "2/5 Deploying the Submariner operator" {
+		t.Fatalf("unexpected progress annotation: %q", got)
+	}
+}
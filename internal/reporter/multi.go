@@ -0,0 +1,79 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reporter
+
+import "github.com/submariner-io/submariner-operator/pkg/eventreporter"
+
+// multiReporter fans out every call to a set of Reporters, so e.g. a human watching the CLI
+// and automation parsing a JSON event stream can observe the same run.
+type multiReporter struct {
+	reporters []eventreporter.Reporter
+}
+
+// NewMultiReporter returns a Reporter that forwards every call to each of the given reporters,
+// in order.
+func NewMultiReporter(reporters ...eventreporter.Reporter) eventreporter.Reporter {
+	return &multiReporter{reporters: reporters}
+}
+
+func (r *multiReporter) Started(message string, args ...interface{}) {
+	for _, reporter := range r.reporters {
+		reporter.Started(message, args...)
+	}
+}
+
+func (r *multiReporter) Succeeded(message string, args ...interface{}) {
+	for _, reporter := range r.reporters {
+		reporter.Succeeded(message, args...)
+	}
+}
+
+func (r *multiReporter) Warned(message string) {
+	for _, reporter := range r.reporters {
+		reporter.Warned(message)
+	}
+}
+
+func (r *multiReporter) Failed(message string) {
+	for _, reporter := range r.reporters {
+		reporter.Failed(message)
+	}
+}
+
+func (r *multiReporter) EndedWith(err error) {
+	for _, reporter := range r.reporters {
+		reporter.EndedWith(err)
+	}
+}
+
+func (r *multiReporter) Step(current, total int, message string) {
+	for _, reporter := range r.reporters {
+		reporter.Step(current, total, message)
+	}
+}
+
+func (r *multiReporter) SubReporter(name string) eventreporter.Reporter {
+	subReporters := make([]eventreporter.Reporter, 0, len(r.reporters))
+
+	for _, reporter := range r.reporters {
+		subReporters = append(subReporters, reporter.SubReporter(name))
+	}
+
+	return NewMultiReporter(subReporters...)
+}
@@ -0,0 +1,101 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reporter
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/submariner-io/submariner-operator/pkg/eventreporter"
+)
+
+// recordingReporter is an eventreporter.Reporter that records every call it receives, in order,
+// so tests can assert on fan-out and prefixing behaviour.
+type recordingReporter struct {
+	calls []string
+}
+
+func (r *recordingReporter) Started(message string, args ...interface{}) {
+	r.calls = append(r.calls, "Started:"+fmt.Sprintf(message, args...))
+}
+
+func (r *recordingReporter) Succeeded(message string, args ...interface{}) {
+	r.calls = append(r.calls, "Succeeded:"+fmt.Sprintf(message, args...))
+}
+
+func (r *recordingReporter) Warned(message string) {
+	r.calls = append(r.calls, "Warned:"+message)
+}
+
+func (r *recordingReporter) Failed(message string) {
+	r.calls = append(r.calls, "Failed:"+message)
+}
+
+func (r *recordingReporter) EndedWith(err error) {
+	if err != nil {
+		r.calls = append(r.calls, "EndedWith:"+err.Error())
+		return
+	}
+
+	r.calls = append(r.calls, "EndedWith:ok")
+}
+
+func (r *recordingReporter) Step(current, total int, message string) {
+	r.calls = append(r.calls, fmt.Sprintf("Step:%d/%d %s", current, total, message))
+}
+
+func (r *recordingReporter) SubReporter(name string) eventreporter.Reporter {
+	return eventreporter.NewSubReporter(r, name)
+}
+
+func TestMultiReporterFansOutToEachReporterInOrder(t *testing.T) {
+	first := &recordingReporter{}
+	second := &recordingReporter{}
+
+	r := NewMultiReporter(first, second)
+
+	r.Started("working")
+	r.Step(1, 2, "halfway")
+	r.EndedWith(nil)
+
+	expected := []string{"Started:working", "Step:1/2 halfway", "EndedWith:ok"}
+
+	for _, calls := range [][]string{first.calls, second.calls} {
+		if !reflect.DeepEqual(calls, expected) {
+			t.Fatalf("unexpected calls: got %v, want %v", calls, expected)
+		}
+	}
+}
+
+func TestMultiReporterSubReporterPrefixesEachUnderlyingReporter(t *testing.T) {
+	first := &recordingReporter{}
+	second := &recordingReporter{}
+
+	r := NewMultiReporter(first, second)
+
+	sub := r.SubReporter("phase")
+	sub.Started("doing work")
+
+	for _, calls := range [][]string{first.calls, second.calls} {
+		if len(calls) != 1 || calls[0] != "Started:phase: doing work" {
+			t.Fatalf("unexpected calls: %v", calls)
+		}
+	}
+}
@@ -20,11 +20,35 @@ package reporter
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/submariner-io/submariner-operator/internal/cli"
 	"github.com/submariner-io/submariner-operator/pkg/eventreporter"
 )
 
+// OutputFormat selects which Reporter implementation New returns, driven by subctl's global
+// --output flag.
+type OutputFormat string
+
+const (
+	OutputText OutputFormat = "text"
+	OutputJSON OutputFormat = "json"
+)
+
+// New returns the Reporter for the given output format. OutputJSON writes its event stream to
+// os.Stdout alongside the usual CLI status output, so automation and a human watching the
+// terminal both see the same run. It's the library-side entry point subctl's cobra commands wire
+// up to a global --output=text|json flag; that command tree lives outside this package.
+func New(output OutputFormat) eventreporter.Reporter {
+	cliReporter := NewCLIReporter()
+
+	if output != OutputJSON {
+		return cliReporter
+	}
+
+	return NewMultiReporter(cliReporter, NewJSONReporter(os.Stdout))
+}
+
 type cliReporter struct {
 	status *cli.Status
 }
@@ -58,3 +82,11 @@ func (r *cliReporter) Failed(message string) {
 func (r *cliReporter) EndedWith(err error) {
 	r.status.End(cli.CheckForError(err))
 }
+
+func (r *cliReporter) Step(current, total int, message string) {
+	r.status.QueueSuccessMessage(fmt.Sprintf("(%d/%d) %s", current, total, message))
+}
+
+func (r *cliReporter) SubReporter(name string) eventreporter.Reporter {
+	return eventreporter.NewSubReporter(r, name)
+}
@@ -0,0 +1,50 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reporter
+
+import "testing"
+
+func TestNewSelectsCLIReporterForText(t *testing.T) {
+	r := New(OutputText)
+
+	if _, ok := r.(*cliReporter); !ok {
+		t.Fatalf("New(OutputText) = %T, want *cliReporter", r)
+	}
+}
+
+func TestNewWrapsCLIAndJSONReportersForJSON(t *testing.T) {
+	r := New(OutputJSON)
+
+	multi, ok := r.(*multiReporter)
+	if !ok {
+		t.Fatalf("New(OutputJSON) = %T, want *multiReporter", r)
+	}
+
+	if len(multi.reporters) != 2 {
+		t.Fatalf("New(OutputJSON) wraps %d reporters, want 2", len(multi.reporters))
+	}
+
+	if _, ok := multi.reporters[0].(*cliReporter); !ok {
+		t.Errorf("first wrapped reporter = %T, want *cliReporter", multi.reporters[0])
+	}
+
+	if _, ok := multi.reporters[1].(*jsonReporter); !ok {
+		t.Errorf("second wrapped reporter = %T, want *jsonReporter", multi.reporters[1])
+	}
+}
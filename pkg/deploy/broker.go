@@ -19,6 +19,7 @@ limitations under the License.
 package deploy
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -35,6 +36,7 @@ import (
 	"github.com/submariner-io/submariner-operator/pkg/subctl/operator/brokercr"
 	"github.com/submariner-io/submariner-operator/pkg/subctl/operator/submarinerop"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/rest"
 )
 
@@ -46,13 +48,47 @@ type BrokerOptions struct {
 	ImageVersion        string
 	BrokerNamespace     string
 	BrokerSpec          submarinerv1a1.BrokerSpec
+
+	// DryRun, when set, makes Broker report the changes it would make without applying them.
+	DryRun bool
+
+	// AdoptExisting, when set, makes Broker treat an AlreadyExists error from creating the Broker
+	// CR as success instead of failing. It has no effect on how the broker namespace or RBAC are
+	// set up.
+	AdoptExisting bool
+
+	// PSKSource resolves and persists the IPsec PSK. It defaults to a FilePSKSource backed by
+	// brokerDetailsFilename; set it to a SecretPSKSource, a KMSPSKSource, or FallbackPSKSources made
+	// up only of those, to keep the plaintext key out of broker-info.subm.
+	PSKSource PSKSource
 }
 
 var ValidComponents = []string{components.ServiceDiscovery, components.Connectivity}
 
 const brokerDetailsFilename = "broker-info.subm"
 
-func Broker(options *BrokerOptions, restConfigProducer restconfig.Producer, reporter eventreporter.Reporter) error {
+// These are package-level vars, rather than direct calls, purely so tests can substitute fakes
+// for the real cluster-mutating calls without standing up a live cluster.
+var (
+	brokerEnsure       = broker.Ensure
+	submarinerOpEnsure = submarinerop.Ensure
+	brokerCREnsure     = brokercr.Ensure
+	imageForOperator   = image.ForOperator
+)
+
+// finisher is implemented by Reporters that need an explicit terminal signal once the whole
+// Broker run is complete, rather than inferring completion from Started/EndedWith balance: Broker
+// has several sibling top-level phases, and some of its success paths return without ever calling
+// EndedWith, so there's no reliable point at which a stack-depth-based Reporter could infer "done".
+type finisher interface {
+	Finish(err error)
+}
+
+func Broker(options *BrokerOptions, restConfigProducer restconfig.Producer, reporter eventreporter.Reporter) (err error) {
+	if f, ok := reporter.(finisher); ok {
+		defer func() { f.Finish(err) }()
+	}
+
 	componentSet := stringset.New(options.BrokerSpec.Components...)
 
 	if err := isValidComponents(componentSet); err != nil {
@@ -76,15 +112,41 @@ func Broker(options *BrokerOptions, restConfigProducer restconfig.Producer, repo
 		return err
 	}
 
+	if options.DryRun {
+		reporter.Succeeded("Dry run complete; no changes were applied")
+		return nil
+	}
+
+	if options.PSKSource == nil {
+		options.PSKSource = &FilePSKSource{Filename: brokerDetailsFilename}
+	}
+
 	reporter.Started(fmt.Sprintf("Creating %s file", brokerDetailsFilename))
 
-	// If deploy-broker is retried we will attempt to re-use the existing IPsec PSK secret
+	// If deploy-broker is retried we will attempt to re-use the existing IPsec PSK, rather than
+	// silently rotating it and breaking tunnels already established with the old key.
+	var fetchedPSK []byte
+
 	if options.IpsecSubmFile == "" {
-		if _, err := datafile.NewFromFile(brokerDetailsFilename); err == nil {
-			options.IpsecSubmFile = brokerDetailsFilename
-			reporter.Warned(fmt.Sprintf("Reusing IPsec PSK from existing %s", brokerDetailsFilename))
-		} else {
-			reporter.Succeeded(fmt.Sprintf("A new IPsec PSK will be generated for %s", brokerDetailsFilename))
+		psk, err := options.PSKSource.FetchPSK(context.TODO())
+		if err != nil {
+			return errors.Wrap(err, "error fetching the existing IPsec PSK")
+		}
+
+		switch {
+		case psk == nil:
+			reporter.Succeeded("A new IPsec PSK will be generated")
+		case isFileSource(options.PSKSource):
+			// The file source hands the PSK back to datafile.NewFromCluster directly, via the
+			// broker-info.subm file itself.
+			options.IpsecSubmFile = options.PSKSource.(*FilePSKSource).Filename
+			reporter.Warned("Reusing the existing IPsec PSK")
+		default:
+			// Secret/KMS sources return the raw PSK bytes instead of a broker-info.subm file; we
+			// re-apply them onto subctlData once it's built, below, instead of letting
+			// datafile.NewFromCluster generate a new PSK.
+			fetchedPSK = psk
+			reporter.Warned("Reusing the existing IPsec PSK")
 		}
 	}
 
@@ -93,6 +155,10 @@ func Broker(options *BrokerOptions, restConfigProducer restconfig.Producer, repo
 		return errors.Wrap(err, "error retrieving preparing the subm data file")
 	}
 
+	if fetchedPSK != nil && subctlData.IPSecPSK != nil {
+		subctlData.IPSecPSK.Data[pskSecretDataKey] = fetchedPSK
+	}
+
 	newFilename, err := datafile.BackupIfExists(brokerDetailsFilename)
 	if err != nil {
 		return errors.Wrap(err, "error backing up the brokerfile")
@@ -115,50 +181,99 @@ func Broker(options *BrokerOptions, restConfigProducer restconfig.Producer, repo
 		}
 	}
 
+	reporter.Step(4, totalDeploySteps, "Creating the globalnet configmap")
+
 	if err = broker.CreateGlobalnetConfigMap(config, options.BrokerSpec.GlobalnetEnabled, options.BrokerSpec.GlobalnetCIDRRange,
 		options.BrokerSpec.DefaultGlobalnetClusterSize, options.BrokerNamespace); err != nil {
 		return errors.Wrap(err, "error creating globalCIDR configmap on Broker")
 	}
 
+	if subctlData.IPSecPSK != nil {
+		if err := options.PSKSource.StorePSK(context.TODO(), subctlData.IPSecPSK.Data[pskSecretDataKey]); err != nil {
+			return errors.Wrap(err, "error storing the IPsec PSK")
+		}
+	}
+
+	if options.PSKSource.Redacted() {
+		subctlData.IPSecPSK = nil
+		reporter.Warned(fmt.Sprintf("The IPsec PSK was stored via the configured PSKSource; %s will not contain it", brokerDetailsFilename))
+	}
+
+	reporter.Step(5, totalDeploySteps, fmt.Sprintf("Creating %s file", brokerDetailsFilename))
+
 	err = subctlData.WriteToFile(brokerDetailsFilename)
 	reporter.EndedWith(err)
 
 	return errors.Wrap(err, "error writing the broker information")
 }
 
+// totalDeploySteps is the number of phases Broker reports progress for: broker RBAC, operator,
+// broker CR, globalnet configmap and datafile write.
+const totalDeploySteps = 5
+
+// deploy re-applies the broker RBAC, operator and Broker CR to the cluster. It's safe to call
+// repeatedly, by subctl or from an in-cluster operator/addon controller loop: broker.Ensure,
+// submarinerop.Ensure and brokercr.Ensure are re-applied on every call, and an AlreadyExists
+// error from brokercr.Ensure is treated as success when options.AdoptExisting is set.
+//
+// This does not yet implement drift detection: none of the three Ensure calls compares its own
+// desired state (RBAC, operator Deployment image/args, Broker CR spec) against what's live and
+// issues a minimal patch, it just re-applies unconditionally. options.DryRun therefore can only
+// suppress each call wholesale (via its own dryRun parameter for broker.Ensure, or by skipping the
+// call outright for the operator and broker CR, below) rather than reporting a true diff. Doing
+// real drift detection means comparing against live state inside broker.Ensure, submarinerop.Ensure
+// and brokercr.Ensure themselves, which this function only calls and doesn't own.
 func deploy(options *BrokerOptions, reporter eventreporter.Reporter, config *rest.Config) error {
-	reporter.Started("Setting up broker RBAC")
+	reporter.Step(1, totalDeploySteps, "Setting up broker RBAC")
 
-	err := broker.Ensure(config, options.BrokerSpec.Components, false, options.BrokerNamespace)
-	reporter.EndedWith(err)
+	rbacReporter := reporter.SubReporter("broker RBAC")
+	rbacReporter.Started("Setting up broker RBAC")
+
+	err := brokerEnsure(config, options.BrokerSpec.Components, options.DryRun, options.BrokerNamespace)
+	rbacReporter.EndedWith(err)
 
 	if err != nil {
 		return errors.Wrap(err, "error setting up broker RBAC")
 	}
 
-	reporter.Started("Deploying the Submariner operator")
+	reporter.Step(2, totalDeploySteps, "Deploying the Submariner operator")
+
+	operatorReporter := reporter.SubReporter("operator")
+	operatorReporter.Started("Deploying the Submariner operator")
 
-	operatorImage, err := image.ForOperator(options.ImageVersion, options.Repository, nil)
+	operatorImage, err := imageForOperator(options.ImageVersion, options.Repository, nil)
 	if err != nil {
 		return errors.Wrap(err, "error getting Operator image")
 	}
 
-	err = submarinerop.Ensure(reporter, config, constants.OperatorNamespace, operatorImage, options.OperatorDebug)
-	reporter.EndedWith(err)
+	if options.DryRun {
+		operatorReporter.Succeeded("The Submariner operator would be deployed")
+	} else {
+		err = submarinerOpEnsure(operatorReporter, config, constants.OperatorNamespace, operatorImage, options.OperatorDebug)
+		operatorReporter.EndedWith(err)
 
-	if err != nil {
-		return errors.Wrap(err, "error deploying the operator")
+		if err != nil {
+			return errors.Wrap(err, "error deploying the operator")
+		}
 	}
 
-	reporter.Started("Deploying the broker")
+	reporter.Step(3, totalDeploySteps, "Deploying the broker")
+
+	brokerCRReporter := reporter.SubReporter("broker CR")
+	brokerCRReporter.Started("Deploying the broker")
+
+	if options.DryRun {
+		brokerCRReporter.Succeeded("The broker would be deployed")
+		return nil
+	}
 
-	err = brokercr.Ensure(config, options.BrokerNamespace, options.BrokerSpec)
-	if err == nil {
-		reporter.Succeeded("The broker has been deployed")
+	err = brokerCREnsure(config, options.BrokerNamespace, options.BrokerSpec)
+	if err == nil || (options.AdoptExisting && apierrors.IsAlreadyExists(err)) {
+		brokerCRReporter.Succeeded("The broker has been deployed")
 		return nil
 	}
 
-	reporter.Failed("Broker deployment failed")
+	brokerCRReporter.Failed("Broker deployment failed")
 
 	return errors.Wrap(err, "error deploying the broker")
 }
@@ -0,0 +1,179 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	submarinerv1a1 "github.com/submariner-io/submariner-operator/api/submariner/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/eventreporter"
+	"github.com/submariner-io/submariner-operator/pkg/subctl/components"
+	"k8s.io/client-go/rest"
+)
+
+// fakeReporter is an eventreporter.Reporter that records every call it receives, in order, so
+// tests can assert on deploy's Step/SubReporter sequencing without a live cluster.
+type fakeReporter struct {
+	calls []string
+}
+
+func (r *fakeReporter) Started(message string, args ...interface{}) {
+	r.calls = append(r.calls, "Started: "+fmt.Sprintf(message, args...))
+}
+
+func (r *fakeReporter) Succeeded(message string, args ...interface{}) {
+	if message == "" {
+		return
+	}
+
+	r.calls = append(r.calls, "Succeeded: "+fmt.Sprintf(message, args...))
+}
+
+func (r *fakeReporter) Warned(message string) {
+	if message == "" {
+		return
+	}
+
+	r.calls = append(r.calls, "Warned: "+message)
+}
+
+func (r *fakeReporter) Failed(message string) {
+	if message == "" {
+		return
+	}
+
+	r.calls = append(r.calls, "Failed: "+message)
+}
+
+func (r *fakeReporter) EndedWith(err error) {
+	if err != nil {
+		r.calls = append(r.calls, "EndedWith: "+err.Error())
+		return
+	}
+
+	r.calls = append(r.calls, "EndedWith: ok")
+}
+
+func (r *fakeReporter) Step(current, total int, message string) {
+	r.calls = append(r.calls, fmt.Sprintf("Step: (%d/%d) %s", current, total, message))
+}
+
+func (r *fakeReporter) SubReporter(name string) eventreporter.Reporter {
+	return eventreporter.NewSubReporter(r, name)
+}
+
+func stubEnsureCalls(t *testing.T) {
+	t.Helper()
+
+	origBrokerEnsure, origSubmarinerOpEnsure, origBrokerCREnsure, origImageForOperator :=
+		brokerEnsure, submarinerOpEnsure, brokerCREnsure, imageForOperator
+
+	t.Cleanup(func() {
+		brokerEnsure, submarinerOpEnsure, brokerCREnsure, imageForOperator =
+			origBrokerEnsure, origSubmarinerOpEnsure, origBrokerCREnsure, origImageForOperator
+	})
+
+	brokerEnsure = func(_ *rest.Config, _ []string, _ bool, _ string) error { return nil }
+	submarinerOpEnsure = func(_ eventreporter.Reporter, _ *rest.Config, _, _ string, _ bool) error { return nil }
+	brokerCREnsure = func(_ *rest.Config, _ string, _ submarinerv1a1.BrokerSpec) error { return nil }
+	imageForOperator = func(_, _ string, _ map[string]string) (string, error) { return "operator-image", nil }
+}
+
+func TestDeployReportsStepsAndSubReportersInOrder(t *testing.T) {
+	stubEnsureCalls(t)
+
+	reporter := &fakeReporter{}
+	options := &BrokerOptions{
+		BrokerNamespace: "test-broker",
+		BrokerSpec:      submarinerv1a1.BrokerSpec{Components: []string{components.Connectivity}},
+	}
+
+	if err := deploy(options, reporter, nil); err != nil {
+		t.Fatalf("deploy returned an unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"Step: (1/5) Setting up broker RBAC",
+		"Started: broker RBAC: Setting up broker RBAC",
+		"EndedWith: ok",
+		"Step: (2/5) Deploying the Submariner operator",
+		"Started: operator: Deploying the Submariner operator",
+		"EndedWith: ok",
+		"Step: (3/5) Deploying the broker",
+		"Started: broker CR: Deploying the broker",
+		"Succeeded: broker CR: The broker has been deployed",
+	}
+
+	if !reflect.DeepEqual(reporter.calls, expected) {
+		t.Fatalf("unexpected reporter call sequence:\n  got:  %v\n  want: %v", reporter.calls, expected)
+	}
+}
+
+func TestDeployDryRunSkipsTheOperatorApply(t *testing.T) {
+	stubEnsureCalls(t)
+
+	submarinerOpEnsureCalled := false
+	submarinerOpEnsure = func(_ eventreporter.Reporter, _ *rest.Config, _, _ string, _ bool) error {
+		submarinerOpEnsureCalled = true
+		return nil
+	}
+
+	brokerCREnsureCalled := false
+	brokerCREnsure = func(_ *rest.Config, _ string, _ submarinerv1a1.BrokerSpec) error {
+		brokerCREnsureCalled = true
+		return nil
+	}
+
+	reporter := &fakeReporter{}
+	options := &BrokerOptions{
+		DryRun:          true,
+		BrokerNamespace: "test-broker",
+		BrokerSpec:      submarinerv1a1.BrokerSpec{Components: []string{components.Connectivity}},
+	}
+
+	if err := deploy(options, reporter, nil); err != nil {
+		t.Fatalf("deploy returned an unexpected error: %v", err)
+	}
+
+	if submarinerOpEnsureCalled {
+		t.Error("submarinerOpEnsure was called despite DryRun being set")
+	}
+
+	if brokerCREnsureCalled {
+		t.Error("brokerCREnsure was called despite DryRun being set")
+	}
+
+	expected := []string{
+		"Step: (1/5) Setting up broker RBAC",
+		"Started: broker RBAC: Setting up broker RBAC",
+		"EndedWith: ok",
+		"Step: (2/5) Deploying the Submariner operator",
+		"Started: operator: Deploying the Submariner operator",
+		"Succeeded: operator: The Submariner operator would be deployed",
+		"Step: (3/5) Deploying the broker",
+		"Started: broker CR: Deploying the broker",
+		"Succeeded: broker CR: The broker would be deployed",
+	}
+
+	if !reflect.DeepEqual(reporter.calls, expected) {
+		t.Fatalf("unexpected reporter call sequence:\n  got:  %v\n  want: %v", reporter.calls, expected)
+	}
+}
@@ -0,0 +1,231 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/pkg/subctl/datafile"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// pskSecretDataKey is the key under which the raw PSK is stored in a Kubernetes Secret or
+// returned by a KMS/Vault endpoint.
+const pskSecretDataKey = "psk"
+
+// PSKSource resolves and persists the IPsec pre-shared key used to secure the broker, so
+// deploy.Broker can be re-run without reading/writing broker-info.subm on disk when that's
+// undesirable, e.g. when invoked from a controller or CI.
+type PSKSource interface {
+	// FetchPSK returns the existing PSK, or nil if this source doesn't have one yet.
+	FetchPSK(ctx context.Context) ([]byte, error)
+
+	// StorePSK persists psk to this source.
+	StorePSK(ctx context.Context, psk []byte) error
+
+	// Redacted reports whether this source keeps the plaintext PSK out of broker-info.subm.
+	Redacted() bool
+}
+
+// FilePSKSource is the original behaviour: the PSK travels embedded in broker-info.subm.
+type FilePSKSource struct {
+	// Filename is the broker-info.subm path to read the existing PSK from.
+	Filename string
+}
+
+func (s *FilePSKSource) FetchPSK(_ context.Context) ([]byte, error) {
+	data, err := datafile.NewFromFile(s.Filename)
+	if err != nil {
+		// No usable file yet; the caller falls back to generating a new PSK.
+		return nil, nil //nolint:nilnil // Absence isn't an error for a PSKSource.
+	}
+
+	if data.IPSecPSK == nil {
+		return nil, nil //nolint:nilnil // Absence isn't an error for a PSKSource.
+	}
+
+	return data.IPSecPSK.Data[pskSecretDataKey], nil
+}
+
+func (s *FilePSKSource) StorePSK(_ context.Context, _ []byte) error {
+	// Nothing to do: deploy.Broker writes the resolved PSK as part of broker-info.subm itself.
+	return nil
+}
+
+// isFileSource reports whether source is a *FilePSKSource, i.e. reuses the PSK via
+// broker-info.subm itself rather than handing back raw PSK bytes.
+func isFileSource(source PSKSource) bool {
+	_, ok := source.(*FilePSKSource)
+	return ok
+}
+
+func (s *FilePSKSource) Redacted() bool {
+	return false
+}
+
+// SecretPSKSource stores the PSK as a Kubernetes Secret in the broker namespace instead of on
+// the local filesystem.
+type SecretPSKSource struct {
+	Client    kubernetes.Interface
+	Namespace string
+	Name      string
+}
+
+func (s *SecretPSKSource) FetchPSK(ctx context.Context) ([]byte, error) {
+	secret, err := s.Client.CoreV1().Secrets(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
+
+	if apierrors.IsNotFound(err) {
+		return nil, nil //nolint:nilnil // Absence isn't an error for a PSKSource.
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving the PSK secret")
+	}
+
+	return secret.Data[pskSecretDataKey], nil
+}
+
+func (s *SecretPSKSource) StorePSK(ctx context.Context, psk []byte) error {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: s.Name, Namespace: s.Namespace},
+		Data:       map[string][]byte{pskSecretDataKey: psk},
+	}
+
+	_, err := s.Client.CoreV1().Secrets(s.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		_, err = s.Client.CoreV1().Secrets(s.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	}
+
+	return errors.Wrap(err, "error storing the PSK secret")
+}
+
+func (s *SecretPSKSource) Redacted() bool {
+	return true
+}
+
+// KMSPSKSource stores the PSK in an external KMS/Vault reachable over HTTP(S).
+type KMSPSKSource struct {
+	// URL identifies the PSK entry in the external store, e.g. a Vault KV path.
+	URL        string
+	HTTPClient *http.Client
+}
+
+func (s *KMSPSKSource) client() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+
+	return http.DefaultClient
+}
+
+func (s *KMSPSKSource) FetchPSK(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error building the KMS request")
+	}
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving the PSK from KMS")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil //nolint:nilnil // Absence isn't an error for a PSKSource.
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("KMS returned unexpected status %d for %s", resp.StatusCode, s.URL)
+	}
+
+	psk, err := io.ReadAll(resp.Body)
+
+	return psk, errors.Wrap(err, "error reading the PSK response from KMS")
+}
+
+func (s *KMSPSKSource) StorePSK(ctx context.Context, psk []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.URL, bytes.NewReader(psk))
+	if err != nil {
+		return errors.Wrap(err, "error building the KMS request")
+	}
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error storing the PSK in KMS")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("KMS returned unexpected status %d for %s", resp.StatusCode, s.URL)
+	}
+
+	return nil
+}
+
+func (s *KMSPSKSource) Redacted() bool {
+	return true
+}
+
+// FallbackPSKSources fetches from each source in order, returning the first PSK found, and
+// stores to all of them, so e.g. a cluster can be migrated from file-based to Secret-based PSK
+// storage by configuring both.
+type FallbackPSKSources []PSKSource
+
+func (s FallbackPSKSources) FetchPSK(ctx context.Context) ([]byte, error) {
+	for _, source := range s {
+		psk, err := source.FetchPSK(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if psk != nil {
+			return psk, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil // Absence isn't an error for a PSKSource.
+}
+
+func (s FallbackPSKSources) StorePSK(ctx context.Context, psk []byte) error {
+	for _, source := range s {
+		if err := source.StorePSK(ctx, psk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s FallbackPSKSources) Redacted() bool {
+	for _, source := range s {
+		if !source.Redacted() {
+			return false
+		}
+	}
+
+	return true
+}
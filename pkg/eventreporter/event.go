@@ -0,0 +1,73 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventreporter
+
+import "time"
+
+// EventKind identifies which Reporter method produced an Event.
+type EventKind string
+
+const (
+	EventStarted   EventKind = "started"
+	EventSucceeded EventKind = "succeeded"
+	EventWarned    EventKind = "warned"
+	EventFailed    EventKind = "failed"
+	EventEnded     EventKind = "ended"
+	EventProgress  EventKind = "progress"
+)
+
+// EndStatus is the terminal status of an operation, set on an EventEnded Event.
+type EndStatus string
+
+const (
+	EndStatusSuccess EndStatus = "success"
+	EndStatusFailure EndStatus = "failure"
+)
+
+// Event is a single, machine-readable record of a Reporter method call. It's emitted by
+// implementations (such as a JSON reporter) that expose progress to automation instead of,
+// or in addition to, a human-readable CLI.
+type Event struct {
+	// Kind identifies which Reporter method produced this Event.
+	Kind EventKind `json:"kind"`
+
+	// OperationID identifies the Started operation this Event belongs to.
+	OperationID string `json:"operationId"`
+
+	// ParentID is the OperationID of the enclosing operation, if any.
+	ParentID string `json:"parentId,omitempty"`
+
+	// Status is only set on EventEnded, and reflects the error (if any) passed to EndedWith.
+	Status EndStatus `json:"status,omitempty"`
+
+	// Message is the formatted message passed to the Reporter method, if any.
+	Message string `json:"message,omitempty"`
+
+	// Timestamp is when the Reporter method was called.
+	Timestamp time.Time `json:"timestamp"`
+
+	// DurationSeconds is only set on EventEnded, and is the time elapsed since the matching
+	// Started call.
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+
+	// Current and Total are only set on EventProgress, and report the step reached out of the
+	// total number of steps in the current operation.
+	Current int `json:"cur,omitempty"`
+	Total   int `json:"total,omitempty"`
+}
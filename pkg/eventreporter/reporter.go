@@ -34,4 +34,12 @@ type Reporter interface {
 
 	// Warned will report that the last operation has thrown a warning.
 	Warned(message string)
+
+	// Step reports progress within a long-running deploy, e.g. "3 of 5" phases complete.
+	Step(current, total int, message string)
+
+	// SubReporter returns a Reporter for a named phase of the current operation. Messages
+	// reported through it reach the same underlying destination as the parent, tagged with
+	// name so a phase's progress can be told apart from its siblings.
+	SubReporter(name string) Reporter
 }
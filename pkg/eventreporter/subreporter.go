@@ -0,0 +1,82 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventreporter
+
+import "fmt"
+
+// subReporter decorates a Reporter with a name, prefixing every message reported through it.
+// Concrete Reporter implementations can use NewSubReporter to implement their own SubReporter
+// method instead of reimplementing the prefixing themselves.
+type subReporter struct {
+	parent Reporter
+	name   string
+}
+
+// NewSubReporter returns a Reporter for a named phase of parent's current operation.
+func NewSubReporter(parent Reporter, name string) Reporter {
+	return &subReporter{parent: parent, name: name}
+}
+
+func (r *subReporter) Started(message string, args ...interface{}) {
+	// Pass the prefixed, already-formatted message through a literal "%s" verb: parent.Started
+	// formats its message with args again internally, and a '%' surviving into message (e.g.
+	// from an error or resource name) would otherwise be misinterpreted as a format verb.
+	r.parent.Started("%s", r.prefix(fmt.Sprintf(message, args...)))
+}
+
+func (r *subReporter) Succeeded(message string, args ...interface{}) {
+	if message == "" {
+		r.parent.Succeeded("")
+		return
+	}
+
+	r.parent.Succeeded("%s", r.prefix(fmt.Sprintf(message, args...)))
+}
+
+func (r *subReporter) Warned(message string) {
+	if message == "" {
+		return
+	}
+
+	r.parent.Warned(r.prefix(message))
+}
+
+func (r *subReporter) Failed(message string) {
+	if message == "" {
+		return
+	}
+
+	r.parent.Failed(r.prefix(message))
+}
+
+func (r *subReporter) EndedWith(err error) {
+	r.parent.EndedWith(err)
+}
+
+func (r *subReporter) Step(current, total int, message string) {
+	r.parent.Step(current, total, r.prefix(message))
+}
+
+func (r *subReporter) SubReporter(name string) Reporter {
+	return NewSubReporter(r, name)
+}
+
+func (r *subReporter) prefix(message string) string {
+	return r.name + ": " + message
+}